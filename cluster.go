@@ -115,6 +115,16 @@ type ClusterOptions struct {
 
 	// Timeout settings
 	DialTimeout, ReadTimeout, WriteTimeout, IdleTimeout time.Duration
+
+	// RouteReadsToReplicas sends read-only commands to a replica of the
+	// owning slot instead of its master, trading a window of
+	// replication lag for read throughput that doesn't compete with
+	// writes.
+	RouteReadsToReplicas bool
+
+	// RouteRandomly picks the replica for a read-only command at random
+	// rather than always the first one in ClusterSlotInfo.Addrs[1:].
+	RouteRandomly bool
 }
 
 func (opt *ClusterOptions) getPoolSize() int {
@@ -204,17 +214,27 @@ func parseClusterSlotInfos(rd *bufio.Reader, n int64) (interface{}, error) {
 // HashSlot returns a consistent slot number between 0 and 16383
 // for any given string key
 func HashSlot(key string) int {
-	if s := strings.IndexByte(key, '{'); s > -1 {
-		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
-			key = key[s+1 : s+e+1]
-		}
-	}
+	key = hashTag(key)
 	if key == "" {
 		return rand.Intn(HashSlots)
 	}
 	return int(crc16sum(key)) % HashSlots
 }
 
+// hashTag returns the substring of key between its first '{' and the
+// first '}' after it, or key unchanged if there is no such pair or the
+// substring between them is empty. Cluster-aware clients hash
+// this substring instead of the whole key so that related keys can be
+// forced onto the same slot.
+func hashTag(key string) string {
+	if s := strings.IndexByte(key, '{'); s > -1 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			return key[s+1 : s+e+1]
+		}
+	}
+	return key
+}
+
 // CRC16 implementation according to CCITT standards.
 // Copyright 2001-2010 Georges Menie (www.menie.org)
 // Copyright 2013 The Go Authors. All rights reserved.
@@ -0,0 +1,370 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// maxRedirects is the number of -MOVED/-ASK redirections a single command
+// may follow before giving up.
+const maxRedirects = 16
+
+// redirectsBeforeReload is the number of consecutive redirections observed
+// across commands before the slot map is proactively refreshed via
+// reloadSlots, which asks the known cluster nodes in turn.
+const redirectsBeforeReload = 10
+
+// ClusterClient is a Redis Cluster client. It keeps a cached slot -> node
+// mapping built from `CLUSTER SLOTS` and transparently follows -MOVED and
+// -ASK redirections, dialing new nodes as the cluster topology requires.
+type ClusterClient struct {
+	opt *ClusterOptions
+
+	mu       sync.RWMutex
+	addrs    []string             // known node addresses (seeds + discovered)
+	slots    [HashSlots]*Client   // slot -> master node client
+	replicas [HashSlots][]*Client // slot -> replica node clients, if any
+
+	nodesMu sync.Mutex
+	nodes   map[string]*Client // addr -> client, shared across slots
+
+	redirectCount uint32 // redirections seen in a row, reset on every successful dispatch
+}
+
+// NewClusterClient returns a client that routes commands across the Redis
+// Cluster described by opt. It bootstraps the slot map by issuing
+// `CLUSTER SLOTS` against the seed opt.Addrs.
+func NewClusterClient(opt *ClusterOptions) *ClusterClient {
+	c := &ClusterClient{
+		opt:   opt,
+		addrs: opt.Addrs,
+		nodes: make(map[string]*Client),
+	}
+	c.reloadSlots()
+	return c
+}
+
+// nodeClient returns the shared *Client for addr, dialing and caching it
+// on first use. Each node client gets its own pool of up to opt.PoolSize
+// connections; the pools do not share a single budget across nodes.
+func (c *ClusterClient) nodeClient(addr string) *Client {
+	c.nodesMu.Lock()
+	defer c.nodesMu.Unlock()
+
+	if cl, ok := c.nodes[addr]; ok {
+		return cl
+	}
+	cl := newClient(addr, c.opt.options())
+	c.nodes[addr] = cl
+	return cl
+}
+
+// reloadSlots refreshes the slot map by calling `CLUSTER SLOTS` against one
+// of the known node addresses. It returns the first error encountered
+// trying each address in turn.
+func (c *ClusterClient) reloadSlots() error {
+	c.mu.RLock()
+	addrs := c.addrs
+	c.mu.RUnlock()
+
+	var firstErr error
+	for _, addr := range addrs {
+		cl := c.nodeClient(addr)
+		infos, err := cl.ClusterSlots().Result()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		c.applySlots(infos)
+		return nil
+	}
+	if firstErr == nil {
+		firstErr = errors.New("redis: no reachable cluster nodes")
+	}
+	return firstErr
+}
+
+// applySlots rebuilds the slot map and the set of known node addresses
+// from a `CLUSTER SLOTS` reply.
+func (c *ClusterClient) applySlots(infos []ClusterSlotInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(infos))
+	for _, info := range infos {
+		if len(info.Addrs) == 0 {
+			continue
+		}
+		master := c.nodeClient(info.Addrs[0])
+		var replicas []*Client
+		for _, addr := range info.Addrs[1:] {
+			replicas = append(replicas, c.nodeClient(addr))
+		}
+		for slot := info.Min; slot <= info.Max && slot < HashSlots; slot++ {
+			c.slots[slot] = master
+			c.replicas[slot] = replicas
+		}
+		for _, addr := range info.Addrs {
+			seen[addr] = struct{}{}
+		}
+	}
+
+	addrs := make([]string, 0, len(seen))
+	for addr := range seen {
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) > 0 {
+		c.addrs = addrs
+	}
+	c.redirectCount = 0
+}
+
+// slotMaster returns the cached master *Client owning slot, or nil if
+// the slot map has no entry for it yet.
+func (c *ClusterClient) slotMaster(slot int) *Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.slots[slot]
+}
+
+// slotReplica returns a replica client for slot, chosen according to
+// opt.RouteRandomly, or nil if the slot has no known replicas.
+func (c *ClusterClient) slotReplica(slot int) *Client {
+	c.mu.RLock()
+	replicas := c.replicas[slot]
+	c.mu.RUnlock()
+
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	if c.opt.RouteRandomly {
+		return replicas[rand.Intn(len(replicas))]
+	}
+	return replicas[0]
+}
+
+// cmdSlot extracts the target hash slot for cmd from its first key
+// argument, honouring hash tags via HashSlot.
+func cmdSlot(cmd Cmder) (int, bool) {
+	key, ok := cmdFirstKey(cmd)
+	if !ok {
+		return 0, false
+	}
+	return HashSlot(key), true
+}
+
+// cmdFirstKey extracts the first key argument of cmd using the
+// convention that args()[0] is the command name and args()[1], if
+// present, is the key it operates on.
+func cmdFirstKey(cmd Cmder) (string, bool) {
+	args := cmdArgs(cmd)
+	if len(args) < 2 {
+		return "", false
+	}
+	return args[1], true
+}
+
+type argsCmder interface {
+	args() []string
+}
+
+func cmdArgs(cmd Cmder) []string {
+	if a, ok := cmd.(argsCmder); ok {
+		return a.args()
+	}
+	return nil
+}
+
+// resetCmder is implemented by every Cmder via baseCmd.
+type resetCmder interface {
+	reset()
+}
+
+// cmdReset clears any error cmd picked up on a previous hop. parseReply
+// only ever sets baseCmd.err on failure and never clears it on success,
+// so a command that failed with -MOVED/-ASK and is then retried must be
+// reset before the retry or a successful retry would still report the
+// stale redirect error.
+func cmdReset(cmd Cmder) {
+	if r, ok := cmd.(resetCmder); ok {
+		r.reset()
+	}
+}
+
+// Process sends cmd to the node owning its key's slot, following -MOVED
+// and -ASK redirections as the cluster reports them. Read-only commands
+// are routed to a replica instead of the master when opt.RouteReadsToReplicas
+// is set.
+func (c *ClusterClient) Process(cmd Cmder) {
+	c.process(cmd, c.opt.RouteReadsToReplicas)
+}
+
+func (c *ClusterClient) process(cmd Cmder, routeToReplica bool) {
+	slot, ok := cmdSlot(cmd)
+	if !ok {
+		cmd.setErr(errors.New("redis: cluster client cannot route a commandless key"))
+		return
+	}
+
+	var node *Client
+	replica := false
+	if routeToReplica && isReadOnlyCmd(cmd) {
+		if node = c.slotReplica(slot); node != nil {
+			replica = true
+		}
+	}
+	if node == nil {
+		node = c.slotMaster(slot)
+	}
+	if node == nil {
+		if err := c.reloadSlots(); err != nil {
+			cmd.setErr(err)
+			return
+		}
+		node = c.slotMaster(slot)
+		if node == nil {
+			node = c.nodeClient(c.firstAddr())
+		}
+	}
+
+	for attempt := 0; attempt <= maxRedirects; attempt++ {
+		if attempt > 0 {
+			// A previous hop may have left a -MOVED/-ASK error on cmd;
+			// parseReply never clears baseCmd.err on success, so a
+			// retry that succeeds would otherwise still report it.
+			cmdReset(cmd)
+		}
+		if replica {
+			// Only the first hop may target a replica; any
+			// redirection after this always lands on a master.
+			dispatchReadOnly(node, cmd)
+			replica = false
+		} else {
+			node.Process(cmd)
+		}
+		err := cmd.Err()
+		if err == nil {
+			c.mu.Lock()
+			c.redirectCount = 0
+			c.mu.Unlock()
+			return
+		}
+
+		moved, ask, addr := parseRedirectError(err)
+		if !moved && !ask {
+			return
+		}
+
+		c.mu.Lock()
+		c.redirectCount++
+		reload := c.redirectCount >= redirectsBeforeReload
+		c.mu.Unlock()
+		if reload {
+			c.reloadSlots()
+		}
+
+		if ask {
+			// ASKING only affects the connection it is sent on, so it
+			// must be paired with cmd through a single-node pipeline
+			// rather than two independent Process calls.
+			c.askRedirect(addr, cmd)
+			return
+		}
+
+		node = c.nodeClient(addr)
+		c.mu.Lock()
+		if slot >= 0 && slot < HashSlots {
+			c.slots[slot] = node
+		}
+		c.mu.Unlock()
+	}
+}
+
+// firstAddr returns the first known node address, guarding the read
+// against concurrent updates from applySlots.
+func (c *ClusterClient) firstAddr() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.addrs[0]
+}
+
+// askRedirect issues ASKING immediately followed by cmd on the same
+// pooled connection to the node at addr, via a single-node pipeline, as
+// required by a -ASK reply. Any further redirection it turns up is
+// handled by feeding cmd back through the normal process path.
+func (c *ClusterClient) askRedirect(addr string, cmd Cmder) {
+	// cmd arrives here carrying the -ASK error that triggered the
+	// redirect; clear it before sending or a successful retry would
+	// still report the stale error.
+	cmdReset(cmd)
+
+	node := c.nodeClient(addr)
+	pipe := node.Pipeline()
+	pipe.Process(NewStatusCmd("ASKING"))
+	pipe.Process(cmd)
+	pipe.Exec()
+
+	err := cmd.Err()
+	if err == nil {
+		c.mu.Lock()
+		c.redirectCount = 0
+		c.mu.Unlock()
+		return
+	}
+	moved, ask, addr2 := parseRedirectError(err)
+	if !moved && !ask {
+		return
+	}
+	if moved {
+		if slot, ok := cmdSlot(cmd); ok {
+			c.mu.Lock()
+			c.slots[slot] = c.nodeClient(addr2)
+			c.mu.Unlock()
+		}
+	}
+	// The first hop of process does not reset cmd, so clear the redirect
+	// error here or a successful retry would still report it.
+	cmdReset(cmd)
+	c.process(cmd, false)
+}
+
+// parseRedirectError reports whether err is a -MOVED or -ASK reply and,
+// if so, the address it points to.
+func parseRedirectError(err error) (moved, ask bool, addr string) {
+	s := err.Error()
+	switch {
+	case strings.HasPrefix(s, "MOVED "):
+		moved = true
+	case strings.HasPrefix(s, "ASK "):
+		ask = true
+	default:
+		return false, false, ""
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return false, false, ""
+	}
+	return moved, ask, fields[2]
+}
+
+// Close closes every pooled connection to every node the client has
+// dialed so far.
+func (c *ClusterClient) Close() error {
+	c.nodesMu.Lock()
+	defer c.nodesMu.Unlock()
+
+	var firstErr error
+	for addr, cl := range c.nodes {
+		if err := cl.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("redis: closing node %s: %s", addr, err)
+		}
+	}
+	return firstErr
+}
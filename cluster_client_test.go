@@ -0,0 +1,29 @@
+package redis
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRedirectError(t *testing.T) {
+	tests := []struct {
+		err       error
+		wantMoved bool
+		wantAsk   bool
+		wantAddr  string
+	}{
+		{errors.New("MOVED 3999 127.0.0.1:6381"), true, false, "127.0.0.1:6381"},
+		{errors.New("ASK 3999 127.0.0.1:6381"), false, true, "127.0.0.1:6381"},
+		{errors.New("ERR unknown command"), false, false, ""},
+		{errors.New("MOVED 3999"), false, false, ""},
+		{errors.New("CLUSTERDOWN The cluster is down"), false, false, ""},
+	}
+
+	for _, tt := range tests {
+		moved, ask, addr := parseRedirectError(tt.err)
+		if moved != tt.wantMoved || ask != tt.wantAsk || addr != tt.wantAddr {
+			t.Errorf("parseRedirectError(%q) = (%v, %v, %q), want (%v, %v, %q)",
+				tt.err, moved, ask, addr, tt.wantMoved, tt.wantAsk, tt.wantAddr)
+		}
+	}
+}
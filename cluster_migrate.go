@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"strconv"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+func (c *Client) ClusterCountKeysInSlot(slot int) *IntCmd {
+	req := NewIntCmd("CLUSTER", "countkeysinslot", strconv.Itoa(slot))
+	c.Process(req)
+	return req
+}
+
+func (c *Client) ClusterGetKeysInSlot(slot, count int) *StringSliceCmd {
+	req := NewStringSliceCmd("CLUSTER", "getkeysinslot", strconv.Itoa(slot), strconv.Itoa(count))
+	c.Process(req)
+	return req
+}
+
+// ClusterSetSlot assigns slot to nodeID or changes its migration state.
+// state is one of "IMPORTING", "MIGRATING", "STABLE", or "NODE"; nodeID
+// is required for all but "STABLE".
+func (c *Client) ClusterSetSlot(slot int, state string, nodeID string) *StatusCmd {
+	args := []string{"CLUSTER", "setslot", strconv.Itoa(slot), state}
+	if nodeID != "" {
+		args = append(args, nodeID)
+	}
+	req := NewStatusCmd(args...)
+	c.Process(req)
+	return req
+}
+
+func (c *Client) ClusterDelSlots(slots ...int) *StatusCmd {
+	args := make([]string, len(slots)+2)
+	args[0] = "CLUSTER"
+	args[1] = "delslots"
+	for i, slot := range slots {
+		args[i+2] = strconv.Itoa(slot)
+	}
+	req := NewStatusCmd(args...)
+	c.Process(req)
+	return req
+}
+
+func (c *Client) ClusterForget(nodeID string) *StatusCmd {
+	req := NewStatusCmd("CLUSTER", "forget", nodeID)
+	c.Process(req)
+	return req
+}
+
+func (c *Client) ClusterFailover() *StatusCmd {
+	req := NewStatusCmd("CLUSTER", "failover")
+	c.Process(req)
+	return req
+}
+
+func (c *Client) ClusterCountFailureReports(nodeID string) *IntCmd {
+	req := NewIntCmd("CLUSTER", "count-failure-reports", nodeID)
+	c.Process(req)
+	return req
+}
+
+// Migrate atomically transfers a key from the current server to the
+// destination Redis instance at host:port, as if by DUMP followed by
+// RESTORE on the destination and DEL on the source. timeout bounds the
+// whole operation. COPY leaves the source key intact and REPLACE
+// overwrites an existing key at the destination; keys may be omitted to
+// migrate a single key, or supplied instead of key to migrate several at
+// once (in which case key must be the empty string).
+func (c *Client) Migrate(host, port, key string, destDB int64, timeout time.Duration, copy, replace bool, keys ...string) *StatusCmd {
+	args := []string{
+		"MIGRATE", host, port, key, strconv.FormatInt(destDB, 10),
+		strconv.FormatInt(int64(timeout/time.Millisecond), 10),
+	}
+	if copy {
+		args = append(args, "COPY")
+	}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	if len(keys) > 0 {
+		args = append(args, "KEYS")
+		args = append(args, keys...)
+	}
+	req := NewStatusCmd(args...)
+	c.Process(req)
+	return req
+}
+
+//------------------------------------------------------------------------------
+
+// migrateBatchSize is the number of keys fetched and migrated per
+// GETKEYSINSLOT/MIGRATE round trip.
+const migrateBatchSize = 100
+
+// ClusterRebalancer drives a single slot's migration from Source to
+// Dest, mirroring the procedure redis-trib uses to reshard a cluster:
+// mark the slot IMPORTING/MIGRATING on both ends, drain keys in batches
+// with MIGRATE, then flip ownership with SETSLOT NODE.
+type ClusterRebalancer struct {
+	Source, Dest       *Client
+	SourceID, DestID   string
+	DestHost, DestPort string
+	Timeout            time.Duration
+}
+
+// MigrateSlot moves every key in slot from r.Source to r.Dest.
+func (r *ClusterRebalancer) MigrateSlot(slot int) error {
+	if err := r.Dest.ClusterSetSlot(slot, "IMPORTING", r.SourceID).Err(); err != nil {
+		return err
+	}
+	if err := r.Source.ClusterSetSlot(slot, "MIGRATING", r.DestID).Err(); err != nil {
+		return err
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	for {
+		n, err := r.Source.ClusterCountKeysInSlot(slot).Result()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+
+		keys, err := r.Source.ClusterGetKeysInSlot(slot, migrateBatchSize).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		if err := r.Source.Migrate(r.DestHost, r.DestPort, "", 0, timeout, false, true, keys...).Err(); err != nil {
+			return err
+		}
+	}
+
+	if err := r.Source.ClusterSetSlot(slot, "NODE", r.DestID).Err(); err != nil {
+		return err
+	}
+	return r.Dest.ClusterSetSlot(slot, "NODE", r.DestID).Err()
+}
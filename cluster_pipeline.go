@@ -0,0 +1,283 @@
+package redis
+
+import "sync"
+
+// ClusterPipeline queues commands and, on Exec, groups them by the node
+// owning each command's slot and dispatches each group concurrently,
+// merging replies back into the original order. Individual commands
+// that come back -MOVED or -ASK are retried against the redirected node
+// and the shared slot cache is updated accordingly.
+type ClusterPipeline struct {
+	client *ClusterClient
+	cmds   []Cmder
+}
+
+// Pipeline returns a ClusterPipeline queueing commands for batched
+// execution against c.
+func (c *ClusterClient) Pipeline() *ClusterPipeline {
+	return &ClusterPipeline{client: c}
+}
+
+// Process queues cmd for the next Exec instead of sending it immediately.
+func (p *ClusterPipeline) Process(cmd Cmder) {
+	p.cmds = append(p.cmds, cmd)
+}
+
+// Exec dispatches every queued command, grouped by target node, and
+// returns them in the order they were queued along with the first error
+// encountered. Each group is sent to its node as a single pipeline, one
+// write/read round trip rather than one per command. Commands that come
+// back -MOVED are retried against the new master once the shared slot
+// cache is updated; commands that come back -ASK are resolved in place
+// via ClusterClient.askRedirect, which pairs ASKING with the command on
+// the same connection.
+func (p *ClusterPipeline) Exec() ([]Cmder, error) {
+	cmds := p.cmds
+	p.cmds = nil
+
+	pending := cmds
+
+	for attempt := 0; attempt <= maxRedirects && len(pending) > 0; attempt++ {
+		groups := make(map[*Client][]Cmder)
+		for _, cmd := range pending {
+			slot, ok := cmdSlot(cmd)
+			node := p.client.slotMaster(slot)
+			if !ok || node == nil {
+				cmd.setErr(errNoSlot(cmd, ok))
+				continue
+			}
+			groups[node] = append(groups[node], cmd)
+		}
+
+		var wg sync.WaitGroup
+		for node, group := range groups {
+			wg.Add(1)
+			go func(node *Client, group []Cmder) {
+				defer wg.Done()
+				pipe := node.Pipeline()
+				for _, cmd := range group {
+					pipe.Process(cmd)
+				}
+				pipe.Exec()
+			}(node, group)
+		}
+		wg.Wait()
+
+		var retry []Cmder
+		for _, cmd := range pending {
+			err := cmd.Err()
+			if err == nil {
+				continue
+			}
+			moved, ask, addr := parseRedirectError(err)
+			if !moved && !ask {
+				continue
+			}
+
+			if ask {
+				// askRedirect resets cmd itself before retrying it.
+				p.client.askRedirect(addr, cmd)
+				continue
+			}
+
+			node := p.client.nodeClient(addr)
+			if slot, ok := cmdSlot(cmd); ok {
+				p.client.mu.Lock()
+				p.client.slots[slot] = node
+				p.client.mu.Unlock()
+			}
+			// cmd still carries the -MOVED error; clear it before the
+			// next attempt's pipeline dispatch or a successful retry
+			// would still report the stale redirect error.
+			cmdReset(cmd)
+			retry = append(retry, cmd)
+		}
+		pending = retry
+	}
+
+	var firstErr error
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return cmds, firstErr
+}
+
+func errNoSlot(cmd Cmder, hadKey bool) error {
+	if !hadKey {
+		return errCmdNoKey
+	}
+	return errNoSlotOwner
+}
+
+var (
+	errCmdNoKey    = clusterPipelineError("redis: cluster pipeline cannot route a commandless key")
+	errNoSlotOwner = clusterPipelineError("redis: cluster pipeline has no known owner for slot")
+)
+
+type clusterPipelineError string
+
+func (e clusterPipelineError) Error() string { return string(e) }
+
+//------------------------------------------------------------------------------
+
+// slotGroups splits keys by the slot each belongs to, preserving the
+// original index of every key so results can be reassembled in order.
+func slotGroups(keys []string) map[int][]int {
+	groups := make(map[int][]int)
+	for i, key := range keys {
+		slot := HashSlot(key)
+		groups[slot] = append(groups[slot], i)
+	}
+	return groups
+}
+
+// MGet fetches keys, which may span multiple slots, by issuing one MGET
+// per slot in parallel and reassembling the results in input order.
+func (c *ClusterClient) MGet(keys ...string) *SliceCmd {
+	cmd := NewSliceCmd("MGET")
+	if len(keys) == 0 {
+		return cmd
+	}
+
+	vals := make([]interface{}, len(keys))
+	groups := slotGroups(keys)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for slot, idxs := range groups {
+		wg.Add(1)
+		go func(slot int, idxs []int) {
+			defer wg.Done()
+
+			args := make([]string, 0, len(idxs)+1)
+			args = append(args, "MGET")
+			for _, i := range idxs {
+				args = append(args, keys[i])
+			}
+			sub := NewSliceCmd(args...)
+			c.Process(sub)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err := sub.Err(); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for j, i := range idxs {
+				vals[i] = sub.Val()[j]
+			}
+		}(slot, idxs)
+	}
+	wg.Wait()
+
+	cmd.val = vals
+	if firstErr != nil {
+		cmd.setErr(firstErr)
+	}
+	return cmd
+}
+
+// MSet sets the given key/value pairs, which may span multiple slots, by
+// issuing one MSET per slot in parallel.
+func (c *ClusterClient) MSet(pairs ...string) *StatusCmd {
+	cmd := NewStatusCmd("MSET")
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		cmd.setErr(errMSetOddPairs)
+		return cmd
+	}
+
+	keys := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		keys = append(keys, pairs[i])
+	}
+	groups := slotGroups(keys)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for slot, idxs := range groups {
+		wg.Add(1)
+		go func(slot int, idxs []int) {
+			defer wg.Done()
+
+			args := make([]string, 0, len(idxs)*2+1)
+			args = append(args, "MSET")
+			for _, i := range idxs {
+				args = append(args, pairs[2*i], pairs[2*i+1])
+			}
+			sub := NewStatusCmd(args...)
+			c.Process(sub)
+
+			if err := sub.Err(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(slot, idxs)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		cmd.setErr(firstErr)
+	}
+	return cmd
+}
+
+// Del removes keys, which may span multiple slots, by issuing one DEL
+// per slot in parallel and summing the number of keys removed.
+func (c *ClusterClient) Del(keys ...string) *IntCmd {
+	cmd := NewIntCmd("DEL")
+	if len(keys) == 0 {
+		return cmd
+	}
+	groups := slotGroups(keys)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var total int64
+	var firstErr error
+
+	for slot, idxs := range groups {
+		wg.Add(1)
+		go func(slot int, idxs []int) {
+			defer wg.Done()
+
+			args := make([]string, 0, len(idxs)+1)
+			args = append(args, "DEL")
+			for _, i := range idxs {
+				args = append(args, keys[i])
+			}
+			sub := NewIntCmd(args...)
+			c.Process(sub)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err := sub.Err(); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			total += sub.Val()
+		}(slot, idxs)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		cmd.setErr(firstErr)
+		return cmd
+	}
+	cmd.val = total
+	return cmd
+}
+
+var errMSetOddPairs = clusterPipelineError("redis: MSet requires an even number of arguments")
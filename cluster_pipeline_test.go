@@ -0,0 +1,48 @@
+package redis
+
+import "testing"
+
+func TestSlotGroups(t *testing.T) {
+	keys := []string{"foo", "bar", "baz", "{tag}a", "{tag}b", "qux"}
+
+	groups := slotGroups(keys)
+
+	gotIdxs := 0
+	seen := make(map[int]bool)
+	for slot, idxs := range groups {
+		for _, i := range idxs {
+			if HashSlot(keys[i]) != slot {
+				t.Errorf("index %d (key %q) grouped under slot %d, want %d", i, keys[i], slot, HashSlot(keys[i]))
+			}
+			if seen[i] {
+				t.Errorf("index %d appeared in more than one group", i)
+			}
+			seen[i] = true
+			gotIdxs++
+		}
+	}
+	if gotIdxs != len(keys) {
+		t.Fatalf("slotGroups covered %d indexes, want %d", gotIdxs, len(keys))
+	}
+
+	tagA, tagB := -1, -1
+	for slot, idxs := range groups {
+		for _, i := range idxs {
+			if keys[i] == "{tag}a" {
+				tagA = slot
+			}
+			if keys[i] == "{tag}b" {
+				tagB = slot
+			}
+		}
+	}
+	if tagA != tagB {
+		t.Errorf("keys sharing a hash tag landed in different slot groups: %d != %d", tagA, tagB)
+	}
+}
+
+func TestSlotGroupsEmpty(t *testing.T) {
+	if groups := slotGroups(nil); len(groups) != 0 {
+		t.Errorf("slotGroups(nil) = %v, want empty", groups)
+	}
+}
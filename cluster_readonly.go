@@ -0,0 +1,87 @@
+package redis
+
+// readOnlyCmds lists the commands that are safe to serve from a replica,
+// keyed by the uppercased command name (args()[0]).
+var readOnlyCmds = map[string]bool{
+	"GET":           true,
+	"MGET":          true,
+	"GETRANGE":      true,
+	"STRLEN":        true,
+	"HGET":          true,
+	"HMGET":         true,
+	"HGETALL":       true,
+	"HKEYS":         true,
+	"HVALS":         true,
+	"HLEN":          true,
+	"HEXISTS":       true,
+	"LRANGE":        true,
+	"LLEN":          true,
+	"LINDEX":        true,
+	"SMEMBERS":      true,
+	"SISMEMBER":     true,
+	"SCARD":         true,
+	"SRANDMEMBER":   true,
+	"ZRANGE":        true,
+	"ZRANGEBYSCORE": true,
+	"ZCARD":         true,
+	"ZSCORE":        true,
+	"ZRANK":         true,
+	"EXISTS":        true,
+	"TTL":           true,
+	"PTTL":          true,
+	"TYPE":          true,
+	"SCAN":          true,
+	"HSCAN":         true,
+	"SSCAN":         true,
+	"ZSCAN":         true,
+}
+
+// isReadOnlyCmd reports whether cmd is safe to route to a replica.
+func isReadOnlyCmd(cmd Cmder) bool {
+	args := cmdArgs(cmd)
+	if len(args) == 0 {
+		return false
+	}
+	return readOnlyCmds[args[0]]
+}
+
+// dispatchReadOnly issues READONLY immediately before cmd on the same
+// pooled connection, via a single-node pipeline, and sends cmd to node.
+//
+// READONLY only affects the connection it is sent on, and node draws
+// from a pool of PoolSize connections, so remembering "already sent"
+// per *Client is not enough: the next call may well be handed a
+// different, still-default-mode connection and get -MOVED back to the
+// master. Pairing READONLY with every read through one pipelined
+// round trip is the only way to guarantee they land on the same
+// connection.
+func dispatchReadOnly(node *Client, cmd Cmder) {
+	pipe := node.Pipeline()
+	pipe.Process(NewStatusCmd("READONLY"))
+	pipe.Process(cmd)
+	pipe.Exec()
+}
+
+//------------------------------------------------------------------------------
+
+// ReadOnlyClusterClient is a view over a ClusterClient that forces
+// read-only commands to a replica for the lifetime of the view,
+// regardless of ClusterOptions.RouteReadsToReplicas. It is returned by
+// ClusterClient.WithReadOnly and shares the parent's slot cache and node
+// pools.
+type ReadOnlyClusterClient struct {
+	parent *ClusterClient
+}
+
+// WithReadOnly returns a view of c that routes read-only commands to a
+// replica for this call site, independent of the client-wide
+// RouteReadsToReplicas setting.
+func (c *ClusterClient) WithReadOnly() *ReadOnlyClusterClient {
+	return &ReadOnlyClusterClient{parent: c}
+}
+
+// Process routes cmd exactly as ClusterClient.Process does, except
+// read-only commands always prefer a replica.
+func (r *ReadOnlyClusterClient) Process(cmd Cmder) {
+	r.parent.process(cmd, true)
+}
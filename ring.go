@@ -0,0 +1,179 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"sync"
+)
+
+// ringReplicas is the number of virtual nodes placed on the hash ring for
+// each shard. Higher values spread keys more evenly across shards at the
+// cost of a larger ring to search.
+const ringReplicas = 160
+
+// RingClient shards commands across a fixed set of independent Redis
+// instances using consistent hashing, for deployments that run plain
+// Redis without cluster mode. Unlike ClusterClient it never redirects;
+// the mapping from key to shard is entirely client-side.
+type RingClient struct {
+	mu     sync.RWMutex
+	shards map[string]*Client  // shard name -> client
+	opts   map[string]*Options // shard name -> options, kept to rebuild
+	points []uint32            // sorted hash points
+	owners []string            // owners[i] is the shard name for points[i]
+}
+
+// NewRingClient returns a RingClient sharding across the given named
+// backends.
+func NewRingClient(shards map[string]*Options) *RingClient {
+	c := &RingClient{
+		shards: make(map[string]*Client, len(shards)),
+		opts:   make(map[string]*Options, len(shards)),
+	}
+	for name, opt := range shards {
+		c.shards[name] = NewTCPClient(opt)
+		c.opts[name] = opt
+	}
+	c.rebuild()
+	return c
+}
+
+// Add dials a new shard and adds it to the ring under the given name,
+// rebuilding the ring. If name already exists its old client is replaced.
+func (c *RingClient) Add(name string, opt *Options) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cl, ok := c.shards[name]; ok {
+		cl.Close()
+	}
+	c.shards[name] = NewTCPClient(opt)
+	c.opts[name] = opt
+	c.rebuildLocked()
+}
+
+// Remove closes and drops the shard with the given name, rebuilding the
+// ring without it.
+func (c *RingClient) Remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cl, ok := c.shards[name]; ok {
+		cl.Close()
+	}
+	delete(c.shards, name)
+	delete(c.opts, name)
+	c.rebuildLocked()
+}
+
+// rebuild locks and regenerates the ring.
+func (c *RingClient) rebuild() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuildLocked()
+}
+
+// rebuildLocked regenerates c.points/c.owners from c.shards. The caller
+// must hold c.mu.
+func (c *RingClient) rebuildLocked() {
+	points := make([]uint32, 0, len(c.shards)*ringReplicas)
+	owners := make(map[uint32]string, len(c.shards)*ringReplicas)
+
+	for name := range c.shards {
+		for i := 0; i < ringReplicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s-%d", name, i)))
+			if _, exists := owners[h]; exists {
+				continue
+			}
+			points = append(points, h)
+			owners[h] = name
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+
+	c.points = points
+	c.owners = make([]string, len(points))
+	for i, p := range points {
+		c.owners[i] = owners[p]
+	}
+}
+
+// shardForKey returns the client owning key, or nil if the ring is empty.
+func (c *RingClient) shardForKey(key string) *Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	name := c.ownerLocked(key)
+	if name == "" {
+		return nil
+	}
+	return c.shards[name]
+}
+
+// ownerLocked returns the shard name owning key, or "" if the ring is
+// empty. The caller must hold c.mu.
+func (c *RingClient) ownerLocked(key string) string {
+	if len(c.points) == 0 {
+		return ""
+	}
+
+	h := crc32.ChecksumIEEE([]byte(hashTag(key)))
+	i := sort.Search(len(c.points), func(i int) bool { return c.points[i] >= h })
+	if i == len(c.points) {
+		i = 0
+	}
+	return c.owners[i]
+}
+
+// Process routes cmd to the shard owning its first key argument.
+func (c *RingClient) Process(cmd Cmder) {
+	key, ok := cmdFirstKey(cmd)
+	if !ok {
+		cmd.setErr(errors.New("redis: ring client cannot route a commandless key"))
+		return
+	}
+
+	shard := c.shardForKey(key)
+	if shard == nil {
+		cmd.setErr(errors.New("redis: ring has no shards"))
+		return
+	}
+	shard.Process(cmd)
+}
+
+// ForEachShard runs fn against every shard's client, returning the first
+// error encountered. Useful for fan-out operations like FLUSHDB or SCAN
+// that must be issued to every backend.
+func (c *RingClient) ForEachShard(fn func(*Client) error) error {
+	c.mu.RLock()
+	shards := make([]*Client, 0, len(c.shards))
+	for _, cl := range c.shards {
+		shards = append(shards, cl)
+	}
+	c.mu.RUnlock()
+
+	var firstErr error
+	for _, cl := range shards {
+		if err := fn(cl); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes every shard's client.
+func (c *RingClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for name, cl := range c.shards {
+		if err := cl.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("redis: closing shard %s: %s", name, err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,86 @@
+package redis
+
+import "testing"
+
+func newTestRing(names ...string) *RingClient {
+	r := &RingClient{
+		shards: make(map[string]*Client, len(names)),
+		opts:   make(map[string]*Options, len(names)),
+	}
+	for _, name := range names {
+		opt := &Options{Addr: name}
+		r.shards[name] = NewTCPClient(opt)
+		r.opts[name] = opt
+	}
+	r.rebuild()
+	return r
+}
+
+func (c *RingClient) ownerForKey(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ownerLocked(key)
+}
+
+func TestRingClientOwnerForKeyIsStable(t *testing.T) {
+	r := newTestRing("shard1", "shard2", "shard3")
+
+	for _, key := range []string{"foo", "bar", "baz", "{tag}key1", "{tag}key2"} {
+		first := r.ownerForKey(key)
+		for i := 0; i < 10; i++ {
+			if got := r.ownerForKey(key); got != first {
+				t.Fatalf("ownerForKey(%q) is not stable: got %q, then %q", key, first, got)
+			}
+		}
+	}
+}
+
+func TestRingClientHashTagRoutesTogether(t *testing.T) {
+	r := newTestRing("shard1", "shard2", "shard3")
+
+	a := r.ownerForKey("{user:1}profile")
+	b := r.ownerForKey("{user:1}settings")
+	if a != b {
+		t.Fatalf("keys sharing a hash tag landed on different shards: %q != %q", a, b)
+	}
+}
+
+func TestRingClientDistributesAcrossShards(t *testing.T) {
+	r := newTestRing("shard1", "shard2", "shard3")
+
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := randishKey(i)
+		counts[r.ownerForKey(key)]++
+	}
+
+	for name := range r.shards {
+		if counts[name] == 0 {
+			t.Errorf("shard %q received no keys out of 3000 lookups", name)
+		}
+	}
+}
+
+func TestRingClientRemove(t *testing.T) {
+	r := newTestRing("shard1", "shard2")
+	r.Remove("shard1")
+
+	if _, ok := r.shards["shard1"]; ok {
+		t.Fatal("shard1 should have been removed")
+	}
+	for i := 0; i < 100; i++ {
+		if got := r.ownerForKey(randishKey(i)); got != "shard2" {
+			t.Fatalf("ownerForKey(%q) = %q, want shard2", randishKey(i), got)
+		}
+	}
+}
+
+// randishKey deterministically derives a distinct key from i without
+// relying on math/rand, which this package's tests avoid seeding.
+func randishKey(i int) string {
+	b := make([]byte, 0, 8)
+	for n := i + 1; n > 0; n /= 26 {
+		b = append(b, byte('a'+n%26))
+	}
+	return string(b)
+}
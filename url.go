@@ -0,0 +1,219 @@
+package redis
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// allowedURLParams are the query-string keys ParseURL and ParseClusterURL
+// accept; anything else is rejected so typos fail loudly instead of
+// being silently ignored.
+var allowedURLParams = map[string]bool{
+	"password":      true,
+	"pool_size":     true,
+	"idle_timeout":  true,
+	"dial_timeout":  true,
+	"read_timeout":  true,
+	"write_timeout": true,
+}
+
+// ParseURL parses a Redis connection URI of the form
+//
+//	redis://[:password@]host:port[/db][?pool_size=N&dial_timeout=5s&...]
+//
+// into an *Options suitable for NewTCPClient. The "redis" and "rediss"
+// schemes are both accepted; TLS setup for "rediss" is left to the
+// caller.
+func ParseURL(rawurl string) (*Options, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("redis: invalid URL scheme: %s", u.Scheme)
+	}
+
+	opt := &Options{
+		Addr: u.Host,
+	}
+	if p, ok := u.User.Password(); ok {
+		opt.Password = p
+	} else if p := u.Query().Get("password"); p != "" {
+		opt.Password = p
+	}
+	if db, err := parseURLDB(u.Path); err != nil {
+		return nil, err
+	} else {
+		opt.DB = db
+	}
+	if err := applyURLQuery(u.Query(), opt); err != nil {
+		return nil, err
+	}
+	return opt, nil
+}
+
+// ParseClusterURL parses a Redis Cluster connection URI of the form
+//
+//	redis+cluster://[:password@]host1:port1,host2:port2,.../[?pool_size=N&...]
+//
+// into a *ClusterOptions suitable for NewClusterClient.
+func ParseClusterURL(rawurl string) (*ClusterOptions, error) {
+	const scheme = "redis+cluster://"
+	if !strings.HasPrefix(rawurl, scheme) {
+		return nil, fmt.Errorf("redis: invalid cluster URL scheme in %q", rawurl)
+	}
+	rest := rawurl[len(scheme):]
+
+	var userinfo string
+	if at := strings.IndexByte(rest, '@'); at > -1 {
+		userinfo, rest = rest[:at], rest[at+1:]
+	}
+
+	authority := rest
+	var query string
+	if q := strings.IndexByte(rest, '?'); q > -1 {
+		authority, query = rest[:q], rest[q+1:]
+	}
+	if slash := strings.IndexByte(authority, '/'); slash > -1 {
+		authority = authority[:slash]
+	}
+	if authority == "" {
+		return nil, fmt.Errorf("redis: cluster URL %q has no addresses", rawurl)
+	}
+	addrs := strings.Split(authority, ",")
+	for _, addr := range addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("redis: invalid cluster address %q: %s", addr, err)
+		}
+	}
+
+	opt := &ClusterOptions{Addrs: addrs}
+	if userinfo != "" {
+		if i := strings.IndexByte(userinfo, ':'); i > -1 {
+			opt.Password = userinfo[i+1:]
+		}
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Password == "" {
+		opt.Password = values.Get("password")
+	}
+	if err := applyURLQuery(values, &clusterOptionsQuery{opt}); err != nil {
+		return nil, err
+	}
+	return opt, nil
+}
+
+func parseURLDB(path string) (int64, error) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return 0, nil
+	}
+	db, err := strconv.ParseInt(path, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("redis: invalid db number %q", path)
+	}
+	return db, nil
+}
+
+// timeoutSetter is implemented by *Options and clusterOptionsQuery so
+// applyURLQuery can populate either from the same query values.
+type timeoutSetter interface {
+	setPoolSize(int)
+	setIdleTimeout(time.Duration)
+	setDialTimeout(time.Duration)
+	setReadTimeout(time.Duration)
+	setWriteTimeout(time.Duration)
+}
+
+func applyURLQuery(values url.Values, dst timeoutSetter) error {
+	for key, vals := range values {
+		if !allowedURLParams[key] {
+			return fmt.Errorf("redis: unknown URL query parameter %q", key)
+		}
+		if len(vals) == 0 {
+			continue
+		}
+		v := vals[0]
+
+		switch key {
+		case "pool_size":
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("redis: invalid pool_size %q", v)
+			}
+			dst.setPoolSize(n)
+		case "idle_timeout":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("redis: invalid idle_timeout %q", v)
+			}
+			dst.setIdleTimeout(d)
+		case "dial_timeout":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("redis: invalid dial_timeout %q", v)
+			}
+			dst.setDialTimeout(d)
+		case "read_timeout":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("redis: invalid read_timeout %q", v)
+			}
+			dst.setReadTimeout(d)
+		case "write_timeout":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("redis: invalid write_timeout %q", v)
+			}
+			dst.setWriteTimeout(d)
+		}
+	}
+	return nil
+}
+
+func (o *Options) setPoolSize(n int)               { o.PoolSize = n }
+func (o *Options) setIdleTimeout(d time.Duration)  { o.IdleTimeout = d }
+func (o *Options) setDialTimeout(d time.Duration)  { o.DialTimeout = d }
+func (o *Options) setReadTimeout(d time.Duration)  { o.ReadTimeout = d }
+func (o *Options) setWriteTimeout(d time.Duration) { o.WriteTimeout = d }
+
+// clusterOptionsQuery adapts *ClusterOptions to timeoutSetter.
+type clusterOptionsQuery struct {
+	opt *ClusterOptions
+}
+
+func (q *clusterOptionsQuery) setPoolSize(n int)               { q.opt.PoolSize = n }
+func (q *clusterOptionsQuery) setIdleTimeout(d time.Duration)  { q.opt.IdleTimeout = d }
+func (q *clusterOptionsQuery) setDialTimeout(d time.Duration)  { q.opt.DialTimeout = d }
+func (q *clusterOptionsQuery) setReadTimeout(d time.Duration)  { q.opt.ReadTimeout = d }
+func (q *clusterOptionsQuery) setWriteTimeout(d time.Duration) { q.opt.WriteTimeout = d }
+
+//------------------------------------------------------------------------------
+
+// NewClientURL returns a client configured by parsing rawurl with
+// ParseURL.
+func NewClientURL(rawurl string) (*Client, error) {
+	opt, err := ParseURL(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewTCPClient(opt), nil
+}
+
+// NewClusterClientURL returns a cluster client configured by parsing
+// rawurl with ParseClusterURL.
+func NewClusterClientURL(rawurl string) (*ClusterClient, error) {
+	opt, err := ParseClusterURL(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewClusterClient(opt), nil
+}
@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+		check   func(t *testing.T, opt *Options)
+	}{
+		{
+			url: "redis://:secret@localhost:6379/2?dial_timeout=5s&pool_size=20&read_timeout=3s",
+			check: func(t *testing.T, opt *Options) {
+				if opt.Addr != "localhost:6379" {
+					t.Errorf("Addr = %q, want localhost:6379", opt.Addr)
+				}
+				if opt.Password != "secret" {
+					t.Errorf("Password = %q, want secret", opt.Password)
+				}
+				if opt.DB != 2 {
+					t.Errorf("DB = %d, want 2", opt.DB)
+				}
+				if opt.DialTimeout != 5*time.Second {
+					t.Errorf("DialTimeout = %s, want 5s", opt.DialTimeout)
+				}
+				if opt.PoolSize != 20 {
+					t.Errorf("PoolSize = %d, want 20", opt.PoolSize)
+				}
+				if opt.ReadTimeout != 3*time.Second {
+					t.Errorf("ReadTimeout = %s, want 3s", opt.ReadTimeout)
+				}
+			},
+		},
+		{
+			url: "redis://localhost:6379?password=secret",
+			check: func(t *testing.T, opt *Options) {
+				if opt.Password != "secret" {
+					t.Errorf("Password = %q, want secret", opt.Password)
+				}
+			},
+		},
+		{
+			url: "rediss://localhost:6379",
+			check: func(t *testing.T, opt *Options) {
+				if opt.Addr != "localhost:6379" {
+					t.Errorf("Addr = %q, want localhost:6379", opt.Addr)
+				}
+			},
+		},
+		{url: "http://localhost:6379", wantErr: true},
+		{url: "redis://localhost:6379?bogus=1", wantErr: true},
+		{url: "redis://localhost:6379/notanumber", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		opt, err := ParseURL(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseURL(%q) = nil error, want error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseURL(%q) returned error: %s", tt.url, err)
+			continue
+		}
+		tt.check(t, opt)
+	}
+}
+
+func TestParseClusterURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+		check   func(t *testing.T, opt *ClusterOptions)
+	}{
+		{
+			url: "redis+cluster://host1:6379,host2:6379?password=secret&pool_size=60",
+			check: func(t *testing.T, opt *ClusterOptions) {
+				if len(opt.Addrs) != 2 || opt.Addrs[0] != "host1:6379" || opt.Addrs[1] != "host2:6379" {
+					t.Errorf("Addrs = %v, want [host1:6379 host2:6379]", opt.Addrs)
+				}
+				if opt.Password != "secret" {
+					t.Errorf("Password = %q, want secret", opt.Password)
+				}
+				if opt.PoolSize != 60 {
+					t.Errorf("PoolSize = %d, want 60", opt.PoolSize)
+				}
+			},
+		},
+		{
+			url: "redis+cluster://:secret@host1:6379,host2:6379",
+			check: func(t *testing.T, opt *ClusterOptions) {
+				if opt.Password != "secret" {
+					t.Errorf("Password = %q, want secret", opt.Password)
+				}
+			},
+		},
+		{url: "redis://host1:6379,host2:6379", wantErr: true},
+		{url: "redis+cluster://", wantErr: true},
+		{url: "redis+cluster://host1,host2", wantErr: true},
+		{url: "redis+cluster://host1:6379?bogus=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		opt, err := ParseClusterURL(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseClusterURL(%q) = nil error, want error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseClusterURL(%q) returned error: %s", tt.url, err)
+			continue
+		}
+		tt.check(t, opt)
+	}
+}